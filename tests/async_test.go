@@ -0,0 +1,123 @@
+//go:build async_test
+
+package cardcrypter
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptAsync(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+	crypter := New(WithWorkers(4))
+
+	expected, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+
+	batch := crypter.EncryptAsync(cards, key)
+	ct, err := batch.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, ct)
+
+	// waiting again returns the same result
+	ct, err = batch.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, ct)
+}
+
+func TestEncryptAsyncDone(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		crypter := New(WithWorkers(5))
+
+		start := time.Now()
+		batch := crypter.EncryptAsync(cards, key)
+
+		// the call itself doesn't block on the batch
+		require.Equal(t, time.Duration(0), time.Since(start))
+
+		<-batch.Done()
+		require.Equal(t, 2*time.Second, time.Since(start))
+
+		ct, err := batch.Wait(context.Background())
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+	})
+}
+
+func TestEncryptAsyncWaitContext(t *testing.T) {
+	mockReaderWithTimeout(t, time.Hour)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 1)
+
+		crypter := New(WithWorkers(1))
+		batch := crypter.EncryptAsync(cards, key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// giving up on waiting doesn't cancel the batch
+		_, err := batch.Wait(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		ct, err := batch.Wait(context.Background())
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+	})
+}
+
+func TestEncryptAsyncCancel(t *testing.T) {
+	mockReaderWithTimeout(t, time.Hour)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 100)
+
+		crypter := New(WithWorkers(1))
+		batch := crypter.EncryptAsync(cards, key)
+
+		time.Sleep(time.Second)
+		batch.Cancel()
+
+		// like a timeout, a cancelled batch returns without waiting for the workers
+		start := time.Now()
+		ct, err := batch.Wait(context.Background())
+		require.ErrorIs(t, err, context.Canceled)
+		require.Nil(t, ct)
+		require.Equal(t, time.Duration(0), time.Since(start))
+
+		select {
+		case <-batch.Done():
+		default:
+			require.Fail(t, "cancelled batch is not done")
+		}
+
+		// cancelling doesn't interrupt the read in progress
+		time.Sleep(time.Hour)
+	})
+}
+
+func TestEncryptAsyncError(t *testing.T) {
+	key := []byte("123")
+	cards := testCards(t, 10)
+
+	crypter := New()
+	batch := crypter.EncryptAsync(cards, key)
+
+	ct, err := batch.Wait(context.Background())
+	require.ErrorContains(t, err, "invalid key")
+	require.Nil(t, ct)
+}
@@ -0,0 +1,92 @@
+//go:build batch_error_test
+
+package cardcrypter
+
+import (
+	"crypto/aes"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchErrorReaderFailure(t *testing.T) {
+	mockReaderWithFailures(t, math.MaxInt32)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(1))
+	_, err := crypter.Encrypt(cards, key)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Nil(t, batchErr.Err)
+	require.Len(t, batchErr.Cards, 1)
+	require.Equal(t, 0, batchErr.Cards[0].Index)
+	require.ErrorIs(t, batchErr.Cards[0].Err, errTestReader)
+
+	require.ErrorIs(t, err, errTestReader)
+	require.ErrorContains(t, err, "card 0")
+}
+
+func TestBatchErrorReaderFailureManyWorkers(t *testing.T) {
+	mockReaderWithFailures(t, math.MaxInt32)
+
+	key := testKey(t)
+	cards := testCards(t, 100)
+
+	crypter := New(WithWorkers(4))
+	_, err := crypter.Encrypt(cards, key)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Nil(t, batchErr.Err)
+	require.NotEmpty(t, batchErr.Cards)
+	require.LessOrEqual(t, len(batchErr.Cards), 4)
+
+	// every card failure is reachable through Unwrap() []error
+	require.Len(t, batchErr.Unwrap(), len(batchErr.Cards))
+
+	// failures are ordered by card index
+	require.True(t, slices.IsSortedFunc(batchErr.Cards, func(a, b CardError) int {
+		return a.Index - b.Index
+	}))
+
+	for _, cardErr := range batchErr.Cards {
+		require.GreaterOrEqual(t, cardErr.Index, 0)
+		require.Less(t, cardErr.Index, len(cards))
+		require.ErrorIs(t, cardErr.Err, errTestReader)
+	}
+}
+
+func TestBatchErrorInvalidKey(t *testing.T) {
+	key := []byte("123")
+	cards := testCards(t, 10)
+
+	crypter := New()
+	_, err := crypter.Encrypt(cards, key)
+
+	// batch-level failures don't belong to any card and are reported in Err
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Empty(t, batchErr.Cards)
+	require.ErrorIs(t, batchErr.Err, aes.KeySizeError(3))
+
+	require.ErrorContains(t, err, "invalid key")
+	require.ErrorIs(t, err, aes.KeySizeError(3))
+}
+
+func TestBatchErrorValidation(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(-1))
+	_, err := crypter.Encrypt(cards, key)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Empty(t, batchErr.Cards)
+	require.ErrorContains(t, batchErr.Err, "negative workers")
+}
@@ -0,0 +1,138 @@
+//go:build call_options_test
+
+package cardcrypter
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptWithOptionsDefaults(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 100)
+	crypter := New(WithWorkers(4))
+
+	expected, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+
+	ct, err := crypter.EncryptWithOptions(cards, key)
+	require.NoError(t, err)
+	require.Equal(t, expected, ct)
+}
+
+func TestEncryptWithOptionsWorkers(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 80)
+
+		crypter := New(WithWorkers(1))
+
+		start := time.Now()
+		ct, err := crypter.EncryptWithOptions(cards, key, WithCallWorkers(8))
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+		require.Equal(t, 10*time.Second, time.Since(start))
+
+		// the override doesn't leak into the shared crypter
+		start = time.Now()
+		ct, err = crypter.Encrypt(cards[:10], key)
+		require.NoError(t, err)
+		require.Len(t, ct, 10)
+		require.Equal(t, 10*time.Second, time.Since(start))
+	})
+}
+
+func TestEncryptWithOptionsChunkSize(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		crypter := New(WithWorkers(10))
+
+		// two chunks of five cards keep only two workers busy
+		start := time.Now()
+		ct, err := crypter.EncryptWithOptions(cards, key, WithCallChunkSize(5))
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+		require.Equal(t, 5*time.Second, time.Since(start))
+	})
+}
+
+func TestEncryptWithOptionsShorterTimeout(t *testing.T) {
+	mockReaderWithTimeout(t, time.Minute)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		crypter := New(WithWorkers(1), WithTimeout(time.Hour))
+
+		// the call deadline takes precedence over the crypter's one
+		start := time.Now()
+		ct, err := crypter.EncryptWithOptions(cards, key, WithCallTimeout(time.Second))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Nil(t, ct)
+		require.Equal(t, time.Second, time.Since(start))
+
+		// and doesn't leak into the next call
+		start = time.Now()
+		ct, err = crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+		require.Equal(t, 10*time.Minute, time.Since(start))
+	})
+}
+
+func TestEncryptWithOptionsLongerTimeout(t *testing.T) {
+	mockReaderWithTimeout(t, time.Minute)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		crypter := New(WithWorkers(1), WithTimeout(time.Second))
+
+		start := time.Now()
+		ct, err := crypter.EncryptWithOptions(cards, key, WithCallTimeout(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+		require.Equal(t, 10*time.Minute, time.Since(start))
+
+		start = time.Now()
+		ct, err = crypter.Encrypt(cards, key)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Nil(t, ct)
+		require.Equal(t, time.Second, time.Since(start))
+
+		// the stalled read outlives the call
+		time.Sleep(time.Minute)
+	})
+}
+
+func TestEncryptWithOptionsInvalid(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+	crypter := New()
+
+	_, err := crypter.EncryptWithOptions(cards, key, WithCallWorkers(0))
+	require.Error(t, err)
+
+	_, err = crypter.EncryptWithOptions(cards, key, WithCallWorkers(-1))
+	require.ErrorContains(t, err, "negative workers")
+
+	_, err = crypter.EncryptWithOptions(cards, key, WithCallChunkSize(-1))
+	require.ErrorContains(t, err, "negative chunk size")
+
+	_, err = crypter.EncryptWithOptions(cards, key, WithCallTimeout(-time.Second))
+	require.ErrorContains(t, err, "negative timeout")
+}
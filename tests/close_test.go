@@ -0,0 +1,89 @@
+//go:build close_test
+
+package cardcrypter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseWaitsInFlight(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 3)
+
+		crypter := New(WithWorkers(1))
+		wg := new(sync.WaitGroup)
+
+		wg.Go(func() {
+			ct, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+			require.Len(t, ct, len(cards))
+		})
+
+		// the batch is in flight and blocked on the reader
+		synctest.Wait()
+
+		start := time.Now()
+		err := crypter.Close(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 3*time.Second, time.Since(start))
+
+		wg.Wait()
+	})
+}
+
+func TestCloseRejectsNewBatches(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(4))
+	require.NoError(t, crypter.Close(context.Background()))
+
+	ct, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, ErrClosed)
+	require.Nil(t, ct)
+
+	// closing twice is a no-op
+	require.NoError(t, crypter.Close(context.Background()))
+}
+
+func TestCloseDeadline(t *testing.T) {
+	mockReaderWithTimeout(t, time.Hour)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 1)
+
+		crypter := New(WithWorkers(1))
+		wg := new(sync.WaitGroup)
+
+		wg.Go(func() {
+			_, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+		})
+
+		synctest.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		start := time.Now()
+		err := crypter.Close(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, time.Second, time.Since(start))
+
+		// the in-flight batch still completes, only new ones are rejected
+		_, err = crypter.Encrypt(cards, key)
+		require.ErrorIs(t, err, ErrClosed)
+
+		wg.Wait()
+	})
+}
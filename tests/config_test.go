@@ -0,0 +1,88 @@
+//go:build config_test
+
+package cardcrypter
+
+import (
+	"crypto/fips140"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDefaults(t *testing.T) {
+	setGOMAXPROCS(t, 6)
+
+	cfg := New().Config()
+
+	// defaults are reported as resolved values, not as zero placeholders
+	require.Equal(t, 6, cfg.Workers)
+	require.Equal(t, "AES-GCM", cfg.Suite)
+	require.Equal(t, "hex", cfg.Encoding)
+	require.Equal(t, fips140.Enabled(), cfg.FIPS)
+	require.Equal(t, 12, cfg.NonceSize)
+	require.Equal(t, PartitionContiguous, cfg.Partitioning)
+	require.Equal(t, 1, cfg.RetryAttempts)
+	require.Zero(t, cfg.Timeout)
+	require.Zero(t, cfg.MaxBatch)
+	require.False(t, cfg.StrictIDs)
+}
+
+func TestConfigOptions(t *testing.T) {
+	crypter := New(
+		WithWorkers(4),
+		WithTimeout(time.Minute),
+		WithRetry(3, nil),
+		WithMaxBatch(1000),
+		WithPartitioning(PartitionStrided),
+		WithStrictIDs(),
+	)
+
+	cfg := crypter.Config()
+	require.Equal(t, 4, cfg.Workers)
+	require.Equal(t, time.Minute, cfg.Timeout)
+	require.Equal(t, 3, cfg.RetryAttempts)
+	require.Equal(t, 1000, cfg.MaxBatch)
+	require.Equal(t, PartitionStrided, cfg.Partitioning)
+	require.True(t, cfg.StrictIDs)
+}
+
+func TestConfigWorkerFactor(t *testing.T) {
+	setGOMAXPROCS(t, 8)
+
+	cfg := New(WithWorkerFactor(0.5)).Config()
+	require.Equal(t, 4, cfg.Workers)
+}
+
+func TestConfigSnapshot(t *testing.T) {
+	crypter := New(WithWorkers(4))
+
+	cfg := crypter.Config()
+	cfg.Workers = 100
+	cfg.StrictIDs = true
+
+	require.Equal(t, 4, crypter.Config().Workers)
+	require.False(t, crypter.Config().StrictIDs)
+}
+
+func TestConfigConcurrent(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(4))
+	wg := new(sync.WaitGroup)
+
+	for range 4 {
+		wg.Go(func() {
+			_, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+		})
+
+		wg.Go(func() {
+			require.Equal(t, 4, crypter.Config().Workers)
+		})
+	}
+
+	wg.Wait()
+}
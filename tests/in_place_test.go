@@ -0,0 +1,90 @@
+//go:build in_place_test
+
+package cardcrypter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptInPlace(t *testing.T) {
+	mockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	out := make([]string, len(cards))
+	for i := range out {
+		out[i] = "stale"
+	}
+
+	crypter := New(WithWorkers(4))
+	err := crypter.EncryptInPlace(cards, key, out)
+	require.NoError(t, err)
+
+	requireDecrypts(t, out, cards, key)
+}
+
+func TestEncryptInPlaceReuseBuffer(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 100)
+	crypter := New(WithWorkers(4))
+
+	expected, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+
+	out := make([]string, len(cards))
+	for range 3 {
+		err = crypter.EncryptInPlace(cards, key, out)
+		require.NoError(t, err)
+		require.Equal(t, expected, out)
+	}
+}
+
+func TestEncryptInPlaceLengthMismatch(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 10)
+	crypter := New()
+
+	err := crypter.EncryptInPlace(cards, key, make([]string, 9))
+	require.ErrorContains(t, err, "output length mismatch")
+
+	err = crypter.EncryptInPlace(cards, key, make([]string, 11))
+	require.ErrorContains(t, err, "output length mismatch")
+
+	err = crypter.EncryptInPlace(cards, key, nil)
+	require.ErrorContains(t, err, "output length mismatch")
+}
+
+func TestEncryptInPlaceEmptySlice(t *testing.T) {
+	key := testKey(t)
+	crypter := New(WithWorkers(4))
+
+	err := crypter.EncryptInPlace(nil, key, nil)
+	require.NoError(t, err)
+
+	err = crypter.EncryptInPlace([]Card{}, key, []string{})
+	require.NoError(t, err)
+}
+
+func TestEncryptInPlaceMallocs(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 100)
+	out := make([]string, len(cards))
+
+	crypter := New(WithWorkers(1))
+	encrypt := inspectMallocs(t, func() {
+		crypter.Encrypt(cards, key)
+	})
+
+	inPlace := inspectMallocs(t, func() {
+		crypter.EncryptInPlace(cards, key, out)
+	})
+
+	// the same work as Encrypt minus the result slice, no temporary copy of it either
+	require.Less(t, inPlace, encrypt)
+	require.LessOrEqual(t, inPlace/len(cards), 1)
+}
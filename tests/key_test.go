@@ -0,0 +1,78 @@
+//go:build key_test
+
+package cardcrypter
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKey(t *testing.T) {
+	for bits, size := range map[int]int{128: 16, 192: 24, 256: 32} {
+		key, err := GenerateKey(bits)
+		require.NoError(t, err)
+		require.Len(t, key, size)
+		require.NoError(t, CheckKey(key))
+
+		other, err := GenerateKey(bits)
+		require.NoError(t, err)
+		require.NotEqual(t, key, other)
+	}
+}
+
+func TestGenerateKeyInvalidSize(t *testing.T) {
+	for _, bits := range []int{0, -256, 100, 512} {
+		_, err := GenerateKey(bits)
+		require.ErrorContains(t, err, "invalid key size")
+	}
+}
+
+func TestGenerateKeyUsesCryptoRandReader(t *testing.T) {
+	reader := forceMockReader(t)
+
+	key, err := GenerateKey(256)
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+	require.EqualValues(t, 1, reader.calls.Load())
+
+	reader.failures.Store(1)
+
+	_, err = GenerateKey(256)
+	require.ErrorIs(t, err, errTestReader)
+}
+
+func TestGenerateKeyHex(t *testing.T) {
+	s, err := GenerateKeyHex()
+	require.NoError(t, err)
+	require.Len(t, s, 64)
+
+	key, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	require.NoError(t, CheckKey(key))
+
+	// the key is usable as is
+	ct, err := New().Encrypt(testCards(t, 10), key)
+	require.NoError(t, err)
+	require.Len(t, ct, 10)
+}
+
+func TestCheckKey(t *testing.T) {
+	require.NoError(t, CheckKey(testKey(t)))
+
+	err := CheckKey([]byte("123"))
+	require.ErrorIs(t, err, aes.KeySizeError(3))
+
+	err = CheckKey(nil)
+	require.ErrorIs(t, err, aes.KeySizeError(0))
+
+	err = CheckKey(make([]byte, 32))
+	require.ErrorIs(t, err, ErrWeakKey)
+	require.ErrorContains(t, err, "all-zero")
+
+	err = CheckKey(bytes.Repeat([]byte{0xAA}, 16))
+	require.ErrorIs(t, err, ErrWeakKey)
+}
@@ -0,0 +1,105 @@
+//go:build key_usage_test
+
+package cardcrypter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyFingerprint(t *testing.T) {
+	key := testKey(t)
+	sum := sha256.Sum256(key)
+
+	// usage is reported by fingerprint, the crypter never keeps the key itself
+	require.Equal(t, hex.EncodeToString(sum[:8]), KeyFingerprint(key))
+	require.NotEqual(t, KeyFingerprint(key), KeyFingerprint([]byte("fedcba9876543210fedcba9876543210")))
+}
+
+func TestKeyUsage(t *testing.T) {
+	key := testKey(t)
+	other := []byte("fedcba9876543210fedcba9876543210")
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(4))
+	require.Zero(t, crypter.KeyUsage(KeyFingerprint(key)))
+
+	for range 2 {
+		_, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+	}
+
+	_, err := crypter.Encrypt(cards[:10], other)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 2000, crypter.KeyUsage(KeyFingerprint(key)))
+	require.EqualValues(t, 10, crypter.KeyUsage(KeyFingerprint(other)))
+
+	// usage is tracked per crypter
+	require.Zero(t, New().KeyUsage(KeyFingerprint(key)))
+}
+
+func TestKeyUsageConcurrent(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 100)
+
+	crypter := New(WithWorkers(4))
+	wg := new(sync.WaitGroup)
+
+	for range 10 {
+		wg.Go(func() {
+			_, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+		})
+	}
+
+	wg.Wait()
+	require.EqualValues(t, 1000, crypter.KeyUsage(KeyFingerprint(key)))
+}
+
+func TestKeyUsageFailedBatch(t *testing.T) {
+	key := []byte("123")
+	cards := testCards(t, 10)
+
+	crypter := New()
+	_, err := crypter.Encrypt(cards, key)
+	require.Error(t, err)
+	require.Zero(t, crypter.KeyUsage(KeyFingerprint(key)))
+}
+
+func TestKeyUsageLimit(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	var exceeded atomic.Int64
+	crypter := New(WithWorkers(4), WithUsageLimit(1500, func() {
+		exceeded.Add(1)
+	}))
+
+	_, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Zero(t, exceeded.Load())
+
+	// the limit only warns, encryption keeps working
+	for range 3 {
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+	}
+
+	require.EqualValues(t, 1, exceeded.Load())
+}
+
+func TestKeyUsageLimitInvalid(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithUsageLimit(-1, func() {}))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "negative usage limit")
+}
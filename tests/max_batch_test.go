@@ -0,0 +1,85 @@
+//go:build max_batch_test
+
+package cardcrypter
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptMaxBatchGolden(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	expected, err := New(WithWorkers(4)).Encrypt(cards, key)
+	require.NoError(t, err)
+
+	for _, maxBatch := range []int{1, 7, 100, 999, 1000, 5000} {
+		crypter := New(WithWorkers(4), WithMaxBatch(maxBatch))
+
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Equal(t, expected, ct, "max batch %d", maxBatch)
+	}
+}
+
+func TestEncryptMaxBatchDecrypt(t *testing.T) {
+	reader := forceMockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(4), WithMaxBatch(64))
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+	require.EqualValues(t, len(cards), reader.calls.Load())
+
+	requireDecrypts(t, ct, cards, key)
+}
+
+func TestEncryptMaxBatchSequential(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 100)
+
+		// sub-batches run one after another, each one takes a single round of reads
+		crypter := New(WithWorkers(100), WithMaxBatch(10))
+
+		start := time.Now()
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+		require.Equal(t, 10*time.Second, time.Since(start))
+	})
+}
+
+func TestEncryptMaxBatchWorkersLimit(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(100), WithMaxBatch(10))
+	gNum := inspectNumGoroutines(t, func() {
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+	})
+
+	require.LessOrEqual(t, gNum, 10)
+}
+
+func TestEncryptNegativeMaxBatch(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithMaxBatch(-1))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "negative max batch")
+}
@@ -0,0 +1,83 @@
+//go:build multi_test
+
+package cardcrypter
+
+import (
+	"crypto/aes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptMulti(t *testing.T) {
+	mockReader(t)
+
+	keys := [][]byte{
+		testKey(t),
+		[]byte("fedcba9876543210fedcba9876543210"),
+		[]byte("0123456789abcdef"),
+	}
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(4))
+	res, err := crypter.EncryptMulti(cards, keys)
+	require.NoError(t, err)
+	require.Len(t, res, len(keys))
+
+	for k, key := range keys {
+		require.Len(t, res[k], len(cards))
+		requireDecrypts(t, res[k], cards, key)
+	}
+
+	// every batch is sealed under its own key only
+	_, err = decrypt(t, res[0][:1], []string{cards[0].ID}, keys[1])
+	require.Error(t, err)
+}
+
+func TestEncryptMultiInvalidKey(t *testing.T) {
+	keys := [][]byte{testKey(t), []byte("123")}
+	cards := testCards(t, 10)
+
+	crypter := New()
+	res, err := crypter.EncryptMulti(cards, keys)
+	require.Nil(t, res)
+	require.ErrorContains(t, err, "invalid key")
+	require.ErrorIs(t, err, aes.KeySizeError(3))
+}
+
+func TestEncryptMultiNoKeys(t *testing.T) {
+	cards := testCards(t, 10)
+
+	crypter := New()
+	_, err := crypter.EncryptMulti(cards, nil)
+	require.ErrorContains(t, err, "no keys")
+}
+
+func TestEncryptMultiEmptySlice(t *testing.T) {
+	keys := [][]byte{testKey(t), testKey(t)}
+
+	crypter := New(WithWorkers(4))
+	res, err := crypter.EncryptMulti(nil, keys)
+	require.NoError(t, err)
+	require.Len(t, res, len(keys))
+
+	for k := range keys {
+		require.Len(t, res[k], 0)
+	}
+}
+
+func TestEncryptMultiWorkers(t *testing.T) {
+	keys := [][]byte{testKey(t), testKey(t), testKey(t)}
+	cards := testCards(t, 10_000)
+
+	const workers = 4
+	crypter := New(WithWorkers(workers))
+	gNum := inspectNumGoroutines(t, func() {
+		res, err := crypter.EncryptMulti(cards, keys)
+		require.NoError(t, err)
+		require.Len(t, res, len(keys))
+	})
+
+	// all keys share one pool rather than a pool per key
+	require.LessOrEqual(t, gNum, workers)
+}
@@ -0,0 +1,104 @@
+//go:build nonce_reuse_test
+
+package cardcrypter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the detector remembers exactly the last window nonces per key:
+// a reuse within the window is always reported, and nothing else is
+
+func TestNonceReuseDetected(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 2)
+
+	crypter := New(WithWorkers(1), WithNonceReuseDetector(16))
+	ct, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, ErrNonceReuse)
+	require.Nil(t, ct)
+}
+
+func TestNonceReuseDetectedAcrossBatches(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithWorkers(1), WithNonceReuseDetector(16))
+
+	_, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+
+	_, err = crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, ErrNonceReuse)
+}
+
+func TestNonceReusePerKey(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	cards := testCards(t, 1)
+	crypter := New(WithWorkers(1), WithNonceReuseDetector(16))
+
+	// the same nonce under a different key is not a reuse
+	_, err := crypter.Encrypt(cards, testKey(t))
+	require.NoError(t, err)
+
+	_, err = crypter.Encrypt(cards, []byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+}
+
+func TestNonceReuseSmallestWindow(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 2)
+
+	// the previous nonce is always within the window
+	crypter := New(WithWorkers(1), WithNonceReuseDetector(1))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, ErrNonceReuse)
+}
+
+func TestNonceReuseNoFalsePositives(t *testing.T) {
+	mockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(4), WithNonceReuseDetector(len(cards)))
+	for range 10 {
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+	}
+}
+
+func TestNonceReuseInvalidWindow(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithNonceReuseDetector(0))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "invalid nonce reuse window")
+
+	crypter = New(WithNonceReuseDetector(-1))
+	_, err = crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "invalid nonce reuse window")
+}
+
+func TestNonceReuseDisabledByDefault(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 2)
+
+	crypter := New(WithWorkers(1))
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Equal(t, ct[0][:24], ct[1][:24])
+}
@@ -0,0 +1,49 @@
+//go:build panic_test
+
+package cardcrypter
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPanicError(t *testing.T) {
+	mockReaderWithPanic(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+	prev := runtime.NumGoroutine()
+
+	crypter := New(WithWorkers(4))
+	ct, err := crypter.Encrypt(cards, key)
+	require.Nil(t, ct)
+	require.ErrorIs(t, err, ErrWorkerPanic)
+	require.ErrorContains(t, err, "test reader: broken entropy source")
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, "test reader: broken entropy source", panicErr.Value)
+	require.NotEmpty(t, panicErr.Stack)
+
+	require.Equal(t, prev, runtime.NumGoroutine())
+}
+
+func TestWorkerPanicCrypterReusable(t *testing.T) {
+	reader := mockReaderWithPanic(t)
+
+	key := testKey(t)
+	cards := testCards(t, 100)
+
+	crypter := New(WithWorkers(4))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, ErrWorkerPanic)
+
+	// only the affected batch fails
+	reader.panics = false
+
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+}
@@ -0,0 +1,107 @@
+//go:build partitioning_test
+
+package cardcrypter
+
+import (
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sealTimes(t *testing.T, numCards int, opts ...CrypterOption) []time.Duration {
+	t.Helper()
+
+	key := testKey(t)
+	cards := testCards(t, numCards)
+	crypter := New(opts...)
+
+	mx := new(sync.Mutex)
+	times := make([]time.Duration, numCards)
+
+	start := time.Now()
+	err := crypter.EncryptTo(cards, key, func(i int, ct string) error {
+		mx.Lock()
+		defer mx.Unlock()
+
+		times[i] = time.Since(start)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return times
+}
+
+func TestPartitioningContiguousByDefault(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		times := sealTimes(t, 4, WithWorkers(2))
+
+		// worker 0 owns [0, 2), worker 1 owns [2, 4)
+		require.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second,
+			time.Second, 2 * time.Second,
+		}, times)
+	})
+}
+
+func TestPartitioningStrided(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	synctest.Test(t, func(t *testing.T) {
+		times := sealTimes(t, 4, WithWorkers(2), WithPartitioning(PartitionStrided))
+
+		// worker 0 owns 0 and 2, worker 1 owns 1 and 3
+		require.Equal(t, []time.Duration{
+			time.Second, time.Second,
+			2 * time.Second, 2 * time.Second,
+		}, times)
+	})
+}
+
+func TestPartitioningUneven(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+
+	for _, p := range []Partitioning{PartitionContiguous, PartitionStrided} {
+		synctest.Test(t, func(t *testing.T) {
+			key := testKey(t)
+			cards := testCards(t, 5)
+
+			crypter := New(WithWorkers(2), WithPartitioning(p))
+
+			start := time.Now()
+			ct, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+			require.Len(t, ct, len(cards))
+			require.Equal(t, 3*time.Second, time.Since(start))
+		})
+	}
+}
+
+func TestPartitioningGolden(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	expected, err := New(WithWorkers(1)).Encrypt(cards, key)
+	require.NoError(t, err)
+
+	for _, p := range []Partitioning{PartitionContiguous, PartitionStrided} {
+		ct, err := New(WithWorkers(7), WithPartitioning(p)).Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Equal(t, expected, ct)
+	}
+}
+
+func TestPartitioningUnknown(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithPartitioning(Partitioning(100)))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "unknown partitioning")
+}
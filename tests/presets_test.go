@@ -0,0 +1,58 @@
+//go:build presets_test
+
+package cardcrypter
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresetHighThroughput(t *testing.T) {
+	setGOMAXPROCS(t, 8)
+
+	cfg := New(PresetHighThroughput()).Config()
+	require.Equal(t, 8, cfg.Workers)
+	require.Equal(t, PartitionContiguous, cfg.Partitioning)
+	require.Zero(t, cfg.MaxBatch)
+}
+
+func TestPresetLowMemory(t *testing.T) {
+	setGOMAXPROCS(t, 8)
+
+	high := New(PresetHighThroughput()).Config()
+	cfg := New(PresetLowMemory()).Config()
+
+	// fewer buffers in flight and a bounded result set per sub-batch
+	require.Less(t, cfg.Workers, high.Workers)
+	require.Positive(t, cfg.Workers)
+	require.Positive(t, cfg.MaxBatch)
+	require.LessOrEqual(t, cfg.MaxBatch, 10_000)
+}
+
+func TestPresetOverride(t *testing.T) {
+	// options are applied in order, so later ones refine the preset
+	cfg := New(PresetLowMemory(), WithMaxBatch(5), WithWorkers(2)).Config()
+	require.Equal(t, 5, cfg.MaxBatch)
+	require.Equal(t, 2, cfg.Workers)
+
+	cfg = New(WithWorkers(2), PresetHighThroughput()).Config()
+	require.Equal(t, runtime.GOMAXPROCS(-1), cfg.Workers)
+}
+
+func TestPresetEncrypt(t *testing.T) {
+	mockReaderWithConstant(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	expected, err := New(WithWorkers(1)).Encrypt(cards, key)
+	require.NoError(t, err)
+
+	for _, preset := range []CrypterOption{PresetHighThroughput(), PresetLowMemory()} {
+		ct, err := New(preset).Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Equal(t, expected, ct)
+	}
+}
@@ -0,0 +1,99 @@
+//go:build progress_test
+
+package cardcrypter
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptProgress(t *testing.T) {
+	mockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 10_000)
+
+	// the callback runs on the workers, so it only records what it saw
+	var (
+		calls   atomic.Int64
+		invalid atomic.Int64
+		maxDone atomic.Int64
+	)
+
+	crypter := New(
+		WithWorkers(8),
+		WithProgressStep(100),
+		WithProgress(func(done, total int) {
+			calls.Add(1)
+
+			if total != len(cards) || done > total {
+				invalid.Add(1)
+			}
+
+			for {
+				prev := maxDone.Load()
+				if int64(done) <= prev || maxDone.CompareAndSwap(prev, int64(done)) {
+					break
+				}
+			}
+		}),
+	)
+
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+
+	require.Zero(t, invalid.Load())
+	require.EqualValues(t, len(cards), maxDone.Load())
+	require.EqualValues(t, len(cards)/100, calls.Load())
+}
+
+func TestEncryptProgressPartialStep(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	var last atomic.Int64
+
+	crypter := New(
+		WithWorkers(1),
+		WithProgressStep(3),
+		WithProgress(func(done, total int) {
+			last.Store(int64(done))
+		}),
+	)
+
+	_, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+
+	// the final call reports the whole batch even if it is not a multiple of the step
+	require.EqualValues(t, len(cards), last.Load())
+}
+
+func TestEncryptProgressEmptySlice(t *testing.T) {
+	key := testKey(t)
+
+	var calls atomic.Int64
+	crypter := New(WithProgress(func(done, total int) {
+		calls.Add(1)
+	}))
+
+	out, err := crypter.Encrypt(nil, key)
+	require.NoError(t, err)
+	require.Len(t, out, 0)
+	require.Zero(t, calls.Load(), "progress must not be reported for an empty batch")
+}
+
+func TestEncryptProgressNegativeStep(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(
+		WithProgressStep(-1),
+		WithProgress(func(done, total int) {}),
+	)
+
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "negative progress step")
+}
@@ -0,0 +1,91 @@
+//go:build rand_source_test
+
+package cardcrypter
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandSourceGolden(t *testing.T) {
+	global := forceMockReader(t)
+
+	source := newTestReader(t)
+	source.deterministic = true
+
+	key := testKey(t)
+	cards := []Card{
+		{ID: "card-1", Number: CardNumber{'1', '2', '3', '4', '5', '6', '7', '8', '9', '0', '1', '2', '3', '4', '5', '6'}},
+		{ID: "card-2", Number: CardNumber{'4', '2', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0'}},
+	}
+
+	crypter := New(WithWorkers(1), WithRandSource(source))
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+
+	require.Equal(t, "313131313131313131313131d382eb39f26d725f4616694b2a0fde33cbc718eaf7b4f2d2817e4ce16e4cacd5", ct[0])
+	require.Equal(t, "313131313131313131313131d682e83df76b75574f166849290bdb3590ee92ef27190a828d801187d567faed", ct[1])
+
+	require.EqualValues(t, len(cards), source.calls.Load())
+	require.Zero(t, global.calls.Load())
+}
+
+func TestRandSourcePerCrypter(t *testing.T) {
+	global := forceMockReader(t)
+
+	key := testKey(t)
+	wg := new(sync.WaitGroup)
+
+	sources := make([]*testRandReader, 4)
+	for i := range sources {
+		sources[i] = newTestReader(t)
+	}
+
+	for i, source := range sources {
+		cards := testCards(t, 100*(i+1))
+
+		wg.Go(func() {
+			crypter := New(WithWorkers(4), WithRandSource(source))
+			ct, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+			require.Len(t, ct, len(cards))
+		})
+	}
+
+	wg.Wait()
+
+	for i, source := range sources {
+		require.EqualValues(t, 100*(i+1), source.calls.Load())
+	}
+
+	require.Zero(t, global.calls.Load())
+}
+
+func TestRandSourceNilFallback(t *testing.T) {
+	global := forceMockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(4), WithRandSource(nil))
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+
+	require.EqualValues(t, len(cards), global.calls.Load())
+}
+
+func TestRandSourceFailure(t *testing.T) {
+	source := newTestReader(t)
+	source.failures.Store(1)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(1), WithRandSource(source))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, errTestReader)
+}
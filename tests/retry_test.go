@@ -0,0 +1,92 @@
+//go:build retry_test
+
+package cardcrypter
+
+import (
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptReaderFailureWithoutRetry(t *testing.T) {
+	mockReaderWithFailures(t, 1)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(1))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, errTestReader)
+}
+
+func TestEncryptRetryTransientReaderFailure(t *testing.T) {
+	reader := mockReaderWithFailures(t, 2)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(1), WithRetry(3, nil))
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+
+	require.EqualValues(t, len(cards)+2, reader.calls.Load())
+
+	requireDecrypts(t, ct, cards, key)
+}
+
+func TestEncryptRetryExhausted(t *testing.T) {
+	reader := mockReaderWithFailures(t, math.MaxInt32)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+
+	crypter := New(WithWorkers(1), WithRetry(3, nil))
+	ct, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, errTestReader)
+	require.Nil(t, ct)
+
+	// the batch stops at the first card that ran out of attempts
+	require.EqualValues(t, 3, reader.calls.Load())
+}
+
+func TestEncryptRetryBackoff(t *testing.T) {
+	mockReaderWithFailures(t, 2)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		var attempts []int
+		backoff := func(attempt int) time.Duration {
+			attempts = append(attempts, attempt)
+			return time.Duration(attempt) * time.Second
+		}
+
+		crypter := New(WithWorkers(1), WithRetry(3, backoff))
+
+		start := time.Now()
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+
+		require.Equal(t, []int{1, 2}, attempts)
+		require.Equal(t, 3*time.Second, time.Since(start))
+	})
+}
+
+func TestEncryptRetryInvalidAttempts(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithRetry(0, nil))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "invalid retry attempts")
+
+	crypter = New(WithRetry(-1, nil))
+	_, err = crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "invalid retry attempts")
+}
@@ -0,0 +1,112 @@
+//go:build sink_test
+
+package cardcrypter
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptTo(t *testing.T) {
+	mockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	// the sink is called concurrently from the workers
+	mx := new(sync.Mutex)
+	out := make([]string, len(cards))
+	calls := make([]int, len(cards))
+
+	crypter := New(WithWorkers(4))
+	err := crypter.EncryptTo(cards, key, func(i int, ct string) error {
+		mx.Lock()
+		defer mx.Unlock()
+
+		out[i] = ct
+		calls[i]++
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	for i := range cards {
+		require.Equal(t, 1, calls[i], "card %d", i)
+	}
+
+	requireDecrypts(t, out, cards, key)
+}
+
+func TestEncryptToSinkError(t *testing.T) {
+	reader := forceMockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 100)
+	errSink := errors.New("sink is full")
+
+	calls := 0
+	crypter := New(WithWorkers(1))
+	err := crypter.EncryptTo(cards, key, func(i int, ct string) error {
+		calls++
+		if i == 5 {
+			return errSink
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, errSink)
+
+	// the batch stops at the first rejected result
+	require.Equal(t, 6, calls)
+	require.EqualValues(t, 6, reader.calls.Load())
+}
+
+func TestEncryptToEmptySlice(t *testing.T) {
+	key := testKey(t)
+
+	var calls atomic.Int64
+	crypter := New(WithWorkers(4))
+	err := crypter.EncryptTo(nil, key, func(i int, ct string) error {
+		calls.Add(1)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Zero(t, calls.Load(), "sink must not be called for an empty batch")
+}
+
+func TestEncryptToInvalidKey(t *testing.T) {
+	cards := testCards(t, 10)
+
+	var calls atomic.Int64
+	crypter := New()
+	err := crypter.EncryptTo(cards, []byte("123"), func(i int, ct string) error {
+		calls.Add(1)
+		return nil
+	})
+	require.ErrorContains(t, err, "invalid key")
+	require.Zero(t, calls.Load(), "sink must not be called for an invalid key")
+}
+
+func TestEncryptToMallocs(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 100)
+
+	crypter := New(WithWorkers(1))
+	encrypt := inspectMallocs(t, func() {
+		crypter.Encrypt(cards, key)
+	})
+
+	sink := inspectMallocs(t, func() {
+		crypter.EncryptTo(cards, key, func(i int, ct string) error {
+			return nil
+		})
+	})
+
+	// results go straight to the sink, no slice is collected for them
+	require.Less(t, sink, encrypt)
+	require.LessOrEqual(t, sink/len(cards), 1)
+}
@@ -0,0 +1,71 @@
+//go:build strict_ids_test
+
+package cardcrypter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictIDsDuplicate(t *testing.T) {
+	reader := forceMockReader(t)
+
+	key := testKey(t)
+	cards := testCards(t, 10)
+	cards[7].ID = cards[2].ID
+	cards[9].ID = cards[2].ID
+
+	crypter := New(WithWorkers(4), WithStrictIDs())
+	ct, err := crypter.Encrypt(cards, key)
+	require.Nil(t, ct)
+	require.ErrorIs(t, err, ErrInvalidIDs)
+
+	var idsErr *InvalidIDsError
+	require.ErrorAs(t, err, &idsErr)
+	require.Equal(t, []int{7, 9}, idsErr.Duplicate)
+	require.Empty(t, idsErr.Empty)
+
+	// validation happens up front, before any card is sealed
+	require.Zero(t, reader.calls.Load())
+}
+
+func TestStrictIDsEmpty(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 10)
+	cards[0].ID = ""
+	cards[5].ID = ""
+
+	crypter := New(WithStrictIDs())
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorIs(t, err, ErrInvalidIDs)
+
+	var idsErr *InvalidIDsError
+	require.ErrorAs(t, err, &idsErr)
+	require.Equal(t, []int{0, 5}, idsErr.Empty)
+
+	// empty IDs are reported once, not as duplicates of each other
+	require.Empty(t, idsErr.Duplicate)
+}
+
+func TestStrictIDsValid(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1000)
+
+	crypter := New(WithWorkers(4), WithStrictIDs())
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+}
+
+func TestStrictIDsDisabledByDefault(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 10)
+	cards[1].ID = cards[0].ID
+	cards[2].ID = ""
+
+	crypter := New()
+	ct, err := crypter.Encrypt(cards, key)
+	require.NoError(t, err)
+	require.Len(t, ct, len(cards))
+}
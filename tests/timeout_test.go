@@ -0,0 +1,58 @@
+//go:build timeout_test
+
+package cardcrypter
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptTimeout(t *testing.T) {
+	mockReaderWithTimeout(t, time.Hour)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		crypter := New(WithWorkers(3), WithTimeout(time.Second))
+
+		start := time.Now()
+		ct, err := crypter.Encrypt(cards, key)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Nil(t, ct)
+
+		// Encrypt returns as soon as the deadline fires, not when the stalled reads finish
+		require.Equal(t, time.Second, time.Since(start))
+
+		// a blocked read can't be interrupted, let the workers drain before the bubble exits
+		time.Sleep(time.Hour)
+	})
+}
+
+func TestEncryptTimeoutNotReached(t *testing.T) {
+	mockReaderWithTimeout(t, time.Millisecond)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 10)
+
+		crypter := New(WithWorkers(3), WithTimeout(time.Hour))
+
+		ct, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Len(t, ct, len(cards))
+	})
+}
+
+func TestEncryptNegativeTimeout(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	crypter := New(WithTimeout(-time.Second))
+	_, err := crypter.Encrypt(cards, key)
+	require.ErrorContains(t, err, "negative timeout")
+}
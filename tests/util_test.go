@@ -5,6 +5,7 @@ import (
 	"crypto/cipher"
 	cr "crypto/rand"
 	"encoding/hex"
+	"errors"
 	mr "math/rand/v2"
 	"runtime"
 	"runtime/debug"
@@ -95,6 +96,22 @@ func decrypt(
 	return out, nil
 }
 
+func requireDecrypts(t *testing.T, ciphertexts []string, cards []Card, key []byte) {
+	t.Helper()
+
+	ids := make([]string, len(cards))
+	for i := range cards {
+		ids[i] = cards[i].ID
+	}
+
+	dec, err := decrypt(t, ciphertexts, ids, key)
+	require.NoError(t, err)
+
+	for i := range cards {
+		require.Equal(t, cards[i].Number, dec[i], "card %d", i)
+	}
+}
+
 func inspectMallocs(t *testing.T, f func()) int {
 	debug.SetGCPercent(-1)
 	t.Cleanup(func() {
@@ -144,6 +161,15 @@ func inspectNumGoroutines(t *testing.T, f func()) int {
 	return int(result.Load()) - 2 - 3
 }
 
+func setGOMAXPROCS(t *testing.T, n int) {
+	t.Helper()
+
+	prev := runtime.GOMAXPROCS(n)
+	t.Cleanup(func() {
+		runtime.GOMAXPROCS(prev)
+	})
+}
+
 func forceMockReader(t *testing.T) *testRandReader {
 	prev := cr.Reader
 	t.Cleanup(func() {
@@ -182,6 +208,25 @@ func mockReaderWithTimeout(t *testing.T, timeout time.Duration) *testRandReader
 	return r
 }
 
+// mockReaderWithFailures makes the first failures reads return errTestReader.
+// crypto/rand.Read crashes the whole test binary on a failing Reader,
+// so specs using it require reading with io.ReadFull(rand.Reader, ...).
+func mockReaderWithFailures(t *testing.T, failures int64) *testRandReader {
+	r := forceMockReader(t)
+	r.failures.Store(failures)
+
+	return r
+}
+
+func mockReaderWithPanic(t *testing.T) *testRandReader {
+	r := forceMockReader(t)
+	r.panics = true
+
+	return r
+}
+
+var errTestReader = errors.New("test reader: entropy source unavailable")
+
 type testRandReader struct {
 	mx *sync.Mutex
 
@@ -192,6 +237,9 @@ type testRandReader struct {
 	sleepTime time.Duration
 	sleep     bool
 
+	failures atomic.Int64
+	panics   bool
+
 	deterministic bool
 }
 
@@ -219,6 +267,14 @@ func (r *testRandReader) Read(p []byte) (n int, err error) {
 
 	r.calls.Add(1)
 
+	if r.panics {
+		panic("test reader: broken entropy source")
+	}
+
+	if r.failures.Add(-1) >= 0 {
+		return 0, errTestReader
+	}
+
 	if r.deterministic {
 		for i := 0; i < len(p); i++ {
 			p[i] = '1'
@@ -0,0 +1,80 @@
+//go:build worker_factor_test
+
+package cardcrypter
+
+import (
+	"runtime"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultWorkers(t *testing.T) {
+	setGOMAXPROCS(t, 7)
+	require.Equal(t, 7, DefaultWorkers())
+
+	runtime.GOMAXPROCS(3)
+	require.Equal(t, 3, DefaultWorkers())
+}
+
+func TestWorkerFactor(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+	setGOMAXPROCS(t, 8)
+
+	tests := []struct {
+		factor   float64
+		expected time.Duration
+	}{
+		{factor: 1, expected: time.Second},
+		{factor: 0.5, expected: 2 * time.Second},
+		{factor: 0.25, expected: 4 * time.Second},
+		// never less than one worker
+		{factor: 0.01, expected: 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		synctest.Test(t, func(t *testing.T) {
+			key := testKey(t)
+			cards := testCards(t, 8)
+
+			crypter := New(WithWorkerFactor(tt.factor))
+
+			start := time.Now()
+			ct, err := crypter.Encrypt(cards, key)
+			require.NoError(t, err)
+			require.Len(t, ct, len(cards))
+			require.Equal(t, tt.expected, time.Since(start), "factor %v", tt.factor)
+		})
+	}
+}
+
+func TestWorkerFactorExplicitWorkers(t *testing.T) {
+	mockReaderWithTimeout(t, time.Second)
+	setGOMAXPROCS(t, 8)
+
+	synctest.Test(t, func(t *testing.T) {
+		key := testKey(t)
+		cards := testCards(t, 8)
+
+		// an explicit worker count wins over the factor
+		crypter := New(WithWorkerFactor(0.5), WithWorkers(1))
+
+		start := time.Now()
+		_, err := crypter.Encrypt(cards, key)
+		require.NoError(t, err)
+		require.Equal(t, 8*time.Second, time.Since(start))
+	})
+}
+
+func TestWorkerFactorInvalid(t *testing.T) {
+	key := testKey(t)
+	cards := testCards(t, 1)
+
+	for _, factor := range []float64{0, -0.5} {
+		crypter := New(WithWorkerFactor(factor))
+		_, err := crypter.Encrypt(cards, key)
+		require.ErrorContains(t, err, "invalid worker factor")
+	}
+}